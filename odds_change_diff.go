@@ -0,0 +1,267 @@
+package uof
+
+// OddsChangeDiff carries only the markets and outcomes that changed between
+// two consecutive OddsChange messages for the same EventURN, keyed by
+// (Market.ID, LineID, Outcome.URN). It mirrors OddsChange's shape closely
+// enough that consumers already parsing Market/Outcome can reuse the same
+// field names, but every Market/Outcome it carries is a partial record:
+// unset pointer fields mean "unchanged", not "absent".
+type OddsChangeDiff struct {
+	EventURN  URN          `xml:"event_id,attr" json:"eventURN"`
+	Product   Producer     `xml:"product,attr" json:"product"`
+	Timestamp int64        `xml:"timestamp,attr" json:"timestamp"`
+	Markets   []MarketDiff `xml:"market,omitempty" json:"market,omitempty"`
+}
+
+// MarketDiff is the changed subset of a Market. Outcomes lists only the
+// outcomes within the market whose fields changed; Removed is true if the
+// market was present in the previous snapshot and is no longer reported
+// (Betradar never does this in practice, but Differ detects it defensively).
+type MarketDiff struct {
+	ID            int                    `xml:"id,attr" json:"id"`
+	LineID        int                    `json:"lineID"`
+	Status        *MarketStatus          `xml:"status,attr,omitempty" json:"status,omitempty"`
+	CashoutStatus *Change[CashoutStatus] `xml:"cashout_status,omitempty" json:"cashoutStatus,omitempty"`
+	Outcomes      []OutcomeDiff          `xml:"outcome,omitempty" json:"outcome,omitempty"`
+	Removed       bool                   `xml:"-" json:"removed,omitempty"`
+}
+
+// OutcomeDiff is the changed subset of an Outcome. Only fields that differ
+// from the previous snapshot are set.
+type OutcomeDiff struct {
+	URN           URN              `xml:"id,attr" json:"id"`
+	Odds          *Change[float64] `xml:"odds,omitempty" json:"odds,omitempty"`
+	Probabilities *Change[float64] `xml:"probabilities,omitempty" json:"probabilities,omitempty"`
+	Active        *Change[bool]    `xml:"active,omitempty" json:"active,omitempty"`
+}
+
+// Change carries a field that changed between two OddsChange snapshots, for
+// fields that are themselves nilable in the live Market/Outcome types (e.g.
+// Outcome.Odds, Market.CashoutStatus). A *Change[T] field being nil means
+// "unchanged"; a non-nil *Change[T] with Present false means the field was
+// cleared to nil, distinct from Present true carrying a new Value. A bare
+// *T can't express this third state, which is why diffed fields use Change
+// instead of just mirroring the live type's pointer.
+type Change[T any] struct {
+	Value   T    `xml:"value,attr" json:"value"`
+	Present bool `xml:"present,attr" json:"present"`
+}
+
+func newChange[T any](v T) *Change[T]  { return &Change[T]{Value: v, Present: true} }
+func clearedChange[T any]() *Change[T] { return &Change[T]{} }
+
+// changeFromPtr builds the Change that represents p's value, or a cleared
+// Change if p is nil.
+func changeFromPtr[T any](p *T) *Change[T] {
+	if p == nil {
+		return clearedChange[T]()
+	}
+	return newChange(*p)
+}
+
+// applyChange updates *dst per c: leaves it untouched if c is nil
+// (unchanged), sets it to a new pointer to c.Value if c.Present, or clears
+// it to nil otherwise.
+func applyChange[T any](dst **T, c *Change[T]) {
+	if c == nil {
+		return
+	}
+	if !c.Present {
+		*dst = nil
+		return
+	}
+	v := c.Value
+	*dst = &v
+}
+
+// marketKey uniquely identifies a market line within an event.
+type marketKey struct {
+	id     int
+	lineID int
+}
+
+// Differ computes the delta between two consecutive OddsChange messages for
+// the same EventURN. prev may be nil, in which case the diff is the full
+// contents of cur (a "full snapshot" diff, as a consumer would need on its
+// first message for an event).
+func Differ(prev, cur *OddsChange) *OddsChangeDiff {
+	diff := &OddsChangeDiff{
+		EventURN:  cur.EventURN,
+		Product:   cur.Product,
+		Timestamp: cur.Timestamp,
+	}
+
+	prevMarkets := make(map[marketKey]Market)
+	if prev != nil && prev.Odds != nil {
+		for _, m := range prev.Odds.Markets {
+			prevMarkets[marketKey{m.ID, m.LineID}] = m
+		}
+	}
+
+	if cur.Odds == nil {
+		return diff
+	}
+	for _, m := range cur.Odds.Markets {
+		key := marketKey{m.ID, m.LineID}
+		old, existed := prevMarkets[key]
+		if md, changed := diffMarket(old, m, existed); changed {
+			diff.Markets = append(diff.Markets, md)
+		}
+		delete(prevMarkets, key)
+	}
+	// Anything left in prevMarkets was present before and is gone now.
+	for key := range prevMarkets {
+		diff.Markets = append(diff.Markets, MarketDiff{ID: key.id, LineID: key.lineID, Removed: true})
+	}
+	return diff
+}
+
+func diffMarket(old, cur Market, existed bool) (MarketDiff, bool) {
+	md := MarketDiff{ID: cur.ID, LineID: cur.LineID}
+	changed := !existed
+
+	if !existed || old.Status != cur.Status {
+		s := cur.Status
+		md.Status = &s
+		changed = true
+	}
+	if !existed || !cashoutStatusEqual(old.CashoutStatus, cur.CashoutStatus) {
+		md.CashoutStatus = changeFromPtr(cur.CashoutStatus)
+		changed = true
+	}
+
+	oldOutcomes := make(map[URN]Outcome, len(old.Outcomes))
+	for _, o := range old.Outcomes {
+		oldOutcomes[o.URN] = o
+	}
+	for _, o := range cur.Outcomes {
+		if od, ok := diffOutcome(oldOutcomes[o.URN], o, existed); ok {
+			md.Outcomes = append(md.Outcomes, od)
+			changed = true
+		}
+	}
+	return md, changed
+}
+
+func diffOutcome(old, cur Outcome, marketExisted bool) (OutcomeDiff, bool) {
+	od := OutcomeDiff{URN: cur.URN}
+	changed := !marketExisted
+
+	if !floatPtrEqual(old.Odds, cur.Odds) {
+		od.Odds = changeFromPtr(cur.Odds)
+		changed = true
+	}
+	if !floatPtrEqual(old.Probabilities, cur.Probabilities) {
+		od.Probabilities = changeFromPtr(cur.Probabilities)
+		changed = true
+	}
+	if !boolPtrEqual(old.Active, cur.Active) {
+		od.Active = changeFromPtr(cur.Active)
+		changed = true
+	}
+	return od, changed
+}
+
+// Apply reconstructs the current OddsChange snapshot by layering diff on top
+// of prev. prev is not mutated; the returned OddsChange shares no state with
+// either argument's slices.
+func Apply(prev *OddsChange, diff *OddsChangeDiff) *OddsChange {
+	cur := &OddsChange{
+		EventURN:  diff.EventURN,
+		Product:   diff.Product,
+		Timestamp: diff.Timestamp,
+	}
+
+	prevMarkets := make(map[marketKey]Market)
+	var order []marketKey
+	if prev != nil && prev.Odds != nil {
+		for _, m := range prev.Odds.Markets {
+			key := marketKey{m.ID, m.LineID}
+			prevMarkets[key] = m
+			order = append(order, key)
+		}
+	}
+
+	changed := make(map[marketKey]MarketDiff, len(diff.Markets))
+	var newKeys []marketKey
+	for _, md := range diff.Markets {
+		key := marketKey{md.ID, md.LineID}
+		changed[key] = md
+		if _, existed := prevMarkets[key]; !existed {
+			newKeys = append(newKeys, key)
+		}
+	}
+
+	markets := make([]Market, 0, len(prevMarkets)+len(newKeys))
+	for _, key := range order {
+		md, isChanged := changed[key]
+		if isChanged && md.Removed {
+			continue
+		}
+		// applyMarket also copies the Outcomes slice, so unchanged markets go
+		// through it too (with a zero MarketDiff) to avoid sharing prev's.
+		markets = append(markets, applyMarket(prevMarkets[key], md))
+	}
+	for _, key := range newKeys {
+		markets = append(markets, applyMarket(Market{ID: key.id, LineID: key.lineID}, changed[key]))
+	}
+
+	cur.Odds = &Odds{Markets: markets}
+	return cur
+}
+
+func applyMarket(m Market, md MarketDiff) Market {
+	if md.Status != nil {
+		m.Status = *md.Status
+	}
+	applyChange(&m.CashoutStatus, md.CashoutStatus)
+
+	var outcomes []Outcome
+	if m.Outcomes != nil {
+		outcomes = make([]Outcome, len(m.Outcomes))
+		copy(outcomes, m.Outcomes)
+	}
+	m.Outcomes = outcomes
+	if len(md.Outcomes) == 0 {
+		return m
+	}
+
+	byURN := make(map[URN]int, len(outcomes))
+	for i, o := range outcomes {
+		byURN[o.URN] = i
+	}
+	for _, od := range md.Outcomes {
+		i, ok := byURN[od.URN]
+		if !ok {
+			outcomes = append(outcomes, Outcome{URN: od.URN})
+			i = len(outcomes) - 1
+			byURN[od.URN] = i
+		}
+		applyChange(&outcomes[i].Odds, od.Odds)
+		applyChange(&outcomes[i].Probabilities, od.Probabilities)
+		applyChange(&outcomes[i].Active, od.Active)
+	}
+	m.Outcomes = outcomes
+	return m
+}
+
+func floatPtrEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func boolPtrEqual(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func cashoutStatusEqual(a, b *CashoutStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,167 @@
+package uof
+
+import "testing"
+
+func f64(v float64) *float64 { return &v }
+func bPtr(v bool) *bool      { return &v }
+
+func TestDifferAndApplyRoundTrip(t *testing.T) {
+	prev := &OddsChange{
+		EventURN:  "sr:match:1",
+		Product:   1,
+		Timestamp: 100,
+		Odds: &Odds{Markets: []Market{
+			{ID: 1, LineID: 10, Status: MarketStatusActive, Outcomes: []Outcome{
+				{URN: "1", Odds: f64(2.0), Active: bPtr(true)},
+				{URN: "2", Odds: f64(3.5), Active: bPtr(true)},
+			}},
+			{ID: 2, LineID: 0, Status: MarketStatusActive, Outcomes: []Outcome{
+				{URN: "1", Odds: f64(1.5)},
+			}},
+		}},
+	}
+
+	cur := &OddsChange{
+		EventURN:  "sr:match:1",
+		Product:   1,
+		Timestamp: 200,
+		Odds: &Odds{Markets: []Market{
+			{ID: 1, LineID: 10, Status: MarketStatusActive, Outcomes: []Outcome{
+				{URN: "1", Odds: f64(2.1), Active: bPtr(true)}, // odds changed
+				{URN: "2", Odds: f64(3.5), Active: bPtr(true)}, // unchanged
+			}},
+			// market 2 dropped entirely
+			{ID: 3, LineID: 0, Status: MarketStatusActive, Outcomes: []Outcome{
+				{URN: "1", Odds: f64(4.0)},
+			}}, // brand new market
+		}},
+	}
+
+	diff := Differ(prev, cur)
+
+	if diff.EventURN != cur.EventURN || diff.Timestamp != cur.Timestamp {
+		t.Fatalf("diff header mismatch: %+v", diff)
+	}
+
+	var gotMarket1, gotMarket2Removed, gotMarket3 bool
+	for _, md := range diff.Markets {
+		switch {
+		case md.ID == 1 && md.LineID == 10:
+			gotMarket1 = true
+			if len(md.Outcomes) != 1 || md.Outcomes[0].URN != "1" {
+				t.Fatalf("expected only outcome 1 of market 1 to be in diff, got %+v", md.Outcomes)
+			}
+		case md.ID == 2 && md.Removed:
+			gotMarket2Removed = true
+		case md.ID == 3:
+			gotMarket3 = true
+		}
+	}
+	if !gotMarket1 {
+		t.Error("expected a diff entry for changed market 1")
+	}
+	if !gotMarket2Removed {
+		t.Error("expected market 2 to be reported removed")
+	}
+	if !gotMarket3 {
+		t.Error("expected a diff entry for new market 3")
+	}
+
+	rebuilt := Apply(prev, diff)
+
+	byKey := make(map[marketKey]Market)
+	for _, m := range rebuilt.Odds.Markets {
+		byKey[marketKey{m.ID, m.LineID}] = m
+	}
+
+	if _, ok := byKey[marketKey{2, 0}]; ok {
+		t.Error("removed market 2 should not be present after Apply")
+	}
+	m1, ok := byKey[marketKey{1, 10}]
+	if !ok {
+		t.Fatal("market 1 missing after Apply")
+	}
+	for _, o := range m1.Outcomes {
+		switch o.URN {
+		case "1":
+			if o.Odds == nil || *o.Odds != 2.1 {
+				t.Errorf("outcome 1 odds = %v, want 2.1", o.Odds)
+			}
+		case "2":
+			if o.Odds == nil || *o.Odds != 3.5 {
+				t.Errorf("outcome 2 odds = %v, want 3.5 (carried over unchanged)", o.Odds)
+			}
+		}
+	}
+	m3, ok := byKey[marketKey{3, 0}]
+	if !ok || len(m3.Outcomes) != 1 || m3.Outcomes[0].Odds == nil || *m3.Outcomes[0].Odds != 4.0 {
+		t.Fatalf("new market 3 not reconstructed correctly: %+v", m3)
+	}
+}
+
+func TestDifferAndApplyClearedField(t *testing.T) {
+	cs := CashoutStatus(1)
+	prev := &OddsChange{
+		EventURN: "sr:match:1",
+		Odds: &Odds{Markets: []Market{
+			{ID: 1, LineID: 0, Status: MarketStatusActive, CashoutStatus: &cs, Outcomes: []Outcome{
+				{URN: "1", Odds: f64(2.0), Active: bPtr(true)},
+			}},
+		}},
+	}
+	cur := &OddsChange{
+		EventURN: "sr:match:1",
+		Odds: &Odds{Markets: []Market{
+			// cashout_status and the outcome's odds/active are both dropped,
+			// not just left out of this message - they're genuinely gone.
+			{ID: 1, LineID: 0, Status: MarketStatusActive, Outcomes: []Outcome{
+				{URN: "1"},
+			}},
+		}},
+	}
+
+	diff := Differ(prev, cur)
+	if len(diff.Markets) != 1 {
+		t.Fatalf("expected one changed market, got %d", len(diff.Markets))
+	}
+	md := diff.Markets[0]
+	if md.CashoutStatus == nil || md.CashoutStatus.Present {
+		t.Fatalf("expected market CashoutStatus diff to be a cleared Change, got %+v", md.CashoutStatus)
+	}
+	if len(md.Outcomes) != 1 {
+		t.Fatalf("expected one changed outcome, got %d", len(md.Outcomes))
+	}
+	od := md.Outcomes[0]
+	if od.Odds == nil || od.Odds.Present {
+		t.Fatalf("expected outcome Odds diff to be a cleared Change, got %+v", od.Odds)
+	}
+	if od.Active == nil || od.Active.Present {
+		t.Fatalf("expected outcome Active diff to be a cleared Change, got %+v", od.Active)
+	}
+
+	rebuilt := Apply(prev, diff)
+	m := rebuilt.Odds.Markets[0]
+	if m.CashoutStatus != nil {
+		t.Errorf("CashoutStatus = %v, want nil after Apply clears it", m.CashoutStatus)
+	}
+	o := m.Outcomes[0]
+	if o.Odds != nil {
+		t.Errorf("Odds = %v, want nil after Apply clears it", o.Odds)
+	}
+	if o.Active != nil {
+		t.Errorf("Active = %v, want nil after Apply clears it", o.Active)
+	}
+}
+
+func TestDifferNilPrev(t *testing.T) {
+	cur := &OddsChange{
+		EventURN: "sr:match:1",
+		Odds: &Odds{Markets: []Market{
+			{ID: 1, LineID: 0, Outcomes: []Outcome{{URN: "1", Odds: f64(2.0)}}},
+		}},
+	}
+	diff := Differ(nil, cur)
+	if len(diff.Markets) != 1 {
+		t.Fatalf("expected full snapshot diff with one market, got %d", len(diff.Markets))
+	}
+}
@@ -0,0 +1,153 @@
+package parlay
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// copulaSamples is the number of Monte Carlo draws used to evaluate the
+// joint Gaussian copula CDF. There is no closed form for more than two
+// correlated legs, so jointProbability estimates it by simulation; this
+// sample size keeps the estimate stable to within roughly half a percentage
+// point for the correlation magnitudes this package ships.
+const copulaSamples = 50000
+
+// copulaSeed is fixed so that repeated calls with the same legs and
+// correlation matrix return the same price.
+const copulaSeed = 42
+
+// jointProbability estimates P(X_1 <= z_1, ..., X_n <= z_n) for a
+// multivariate normal vector X with correlation matrix rho, where each
+// z_i = Phi^-1(legs[i].FairProbability). This is the Gaussian copula
+// construction: mapping each leg's marginal probability through the normal
+// quantile function and evaluating the joint CDF of the correlated normal
+// vector reproduces the dependence implied by rho while preserving each
+// leg's own fair probability.
+func jointProbability(legs []Leg, rho [][]float64) (float64, error) {
+	n := len(legs)
+	z := make([]float64, n)
+	for i, l := range legs {
+		z[i] = invNormCDF(l.FairProbability)
+	}
+
+	chol, err := cholesky(rho)
+	if err != nil {
+		return 0, err
+	}
+
+	rng := rand.New(rand.NewSource(copulaSeed))
+	hits := 0
+	x := make([]float64, n)
+	indep := make([]float64, n)
+	for s := 0; s < copulaSamples; s++ {
+		for i := 0; i < n; i++ {
+			indep[i] = rng.NormFloat64()
+		}
+		ok := true
+		for i := 0; i < n; i++ {
+			x[i] = 0
+			for j := 0; j <= i; j++ {
+				x[i] += chol[i][j] * indep[j]
+			}
+			if x[i] > z[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			hits++
+		}
+	}
+	return float64(hits) / float64(copulaSamples), nil
+}
+
+// cholesky returns the lower-triangular Cholesky factor of a symmetric
+// matrix, falling back to the nearest valid factorisation by clamping
+// negative pivots to zero if rho is not positive semi-definite (which can
+// happen when pairwise coefficients are set independently by Override).
+func cholesky(rho [][]float64) ([][]float64, error) {
+	n := len(rho)
+	if n == 0 {
+		return nil, errors.New("parlay: empty correlation matrix")
+	}
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := rho[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum < 0 {
+					sum = 0
+				}
+				l[i][j] = math.Sqrt(sum)
+			} else if l[j][j] != 0 {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
+
+// invNormCDF is the quantile function (inverse CDF) of the standard normal
+// distribution, using Acklam's rational approximation (accurate to better
+// than 1.15e-9 absolute error), which is sufficient precision for pricing.
+func invNormCDF(p float64) float64 {
+	switch {
+	case p <= 0:
+		return math.Inf(-1)
+	case p >= 1:
+		return math.Inf(1)
+	}
+
+	const (
+		a1 = -3.969683028665376e+01
+		a2 = 2.209460984245205e+02
+		a3 = -2.759285104469687e+02
+		a4 = 1.383577518672690e+02
+		a5 = -3.066479806614716e+01
+		a6 = 2.506628277459239e+00
+
+		b1 = -5.447609879822406e+01
+		b2 = 1.615858368580409e+02
+		b3 = -1.556989798598866e+02
+		b4 = 6.680131188771972e+01
+		b5 = -1.328068155288572e+01
+
+		c1 = -7.784894002430293e-03
+		c2 = -3.223964580411365e-01
+		c3 = -2.400758277161838e+00
+		c4 = -2.549732539343734e+00
+		c5 = 4.374664141464968e+00
+		c6 = 2.938163982698783e+00
+
+		d1 = 7.784695709041462e-03
+		d2 = 3.224671290700398e-01
+		d3 = 2.445134137142996e+00
+		d4 = 3.754408661907416e+00
+
+		pLow  = 0.02425
+		pHigh = 1 - pLow
+	)
+
+	switch {
+	case p < pLow:
+		q := math.Sqrt(-2 * math.Log(p))
+		return (((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	case p <= pHigh:
+		q := p - 0.5
+		r := q * q
+		return (((((a1*r+a2)*r+a3)*r+a4)*r+a5)*r + a6) * q /
+			(((((b1*r+b2)*r+b3)*r+b4)*r+b5)*r + 1)
+	default:
+		q := math.Sqrt(-2 * math.Log(1-p))
+		return -(((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	}
+}
@@ -0,0 +1,110 @@
+package parlay
+
+// MarketClass groups market IDs into broad correlation classes. Betradar
+// market IDs are assigned per-bet-type, so the class of a market is a
+// coarser signal than the ID itself (e.g. all "total goals" lines, whatever
+// their specifier, correlate with a match winner market the same way).
+type MarketClass int
+
+const (
+	ClassUnknown MarketClass = iota
+	ClassMatchWinner
+	ClassTotalGoals
+	ClassBothTeamsToScore
+	ClassHandicap
+	ClassPlayerToScore
+	ClassCorrectScore
+)
+
+// marketClassByID maps the common core Betradar market IDs to their
+// correlation class. This is intentionally a small, well-known subset;
+// unrecognised IDs fall back to ClassUnknown, which correlates with nothing.
+var marketClassByID = map[int]MarketClass{
+	1:  ClassMatchWinner,      // 1x2
+	10: ClassBothTeamsToScore, // both teams to score
+	18: ClassTotalGoals,       // total goals over/under
+	16: ClassCorrectScore,     // correct score
+	14: ClassHandicap,         // handicap
+	38: ClassPlayerToScore,    // anytime goalscorer
+	92: ClassPlayerToScore,    // first goalscorer
+}
+
+func classify(marketID int) MarketClass {
+	if c, ok := marketClassByID[marketID]; ok {
+		return c
+	}
+	return ClassUnknown
+}
+
+// CorrelationMatrix holds pairwise Pearson-style correlation coefficients
+// (rho, in [-1, 1]) between market classes, keyed by the unordered pair of
+// class values with the smaller value first.
+type CorrelationMatrix struct {
+	rho map[[2]MarketClass]float64
+}
+
+// DefaultCorrelationMatrix returns the package's shipped correlation table,
+// seeded with commonly observed relationships between core markets (e.g. a
+// home win correlates positively with "over" totals and with the home team
+// scoring first). Unlisted pairs, including any pair involving
+// ClassUnknown, default to rho = 0 (treated as independent).
+func DefaultCorrelationMatrix() *CorrelationMatrix {
+	cm := &CorrelationMatrix{rho: map[[2]MarketClass]float64{
+		pair(ClassMatchWinner, ClassTotalGoals):         0.15,
+		pair(ClassMatchWinner, ClassBothTeamsToScore):   -0.10,
+		pair(ClassMatchWinner, ClassHandicap):           0.55,
+		pair(ClassMatchWinner, ClassCorrectScore):       0.35,
+		pair(ClassMatchWinner, ClassPlayerToScore):      0.20,
+		pair(ClassTotalGoals, ClassBothTeamsToScore):    0.45,
+		pair(ClassTotalGoals, ClassCorrectScore):        0.30,
+		pair(ClassTotalGoals, ClassPlayerToScore):       0.25,
+		pair(ClassBothTeamsToScore, ClassCorrectScore):  0.25,
+		pair(ClassBothTeamsToScore, ClassPlayerToScore): 0.20,
+	}}
+	return cm
+}
+
+// Override sets or replaces correlation coefficients. Keys are unordered
+// pairs of market IDs; the coefficient is applied to the classes those
+// market IDs belong to (falling back to ClassUnknown for unrecognised IDs),
+// which lets a caller tune behaviour for a specific market pair without
+// reclassifying it.
+func (cm *CorrelationMatrix) Override(coefficients map[[2]int]float64) {
+	if cm.rho == nil {
+		cm.rho = make(map[[2]MarketClass]float64)
+	}
+	for ids, v := range coefficients {
+		a, b := classify(ids[0]), classify(ids[1])
+		cm.rho[pair(a, b)] = v
+	}
+}
+
+// Matrix builds the dense correlation matrix for legs, in leg order, using
+// cm's class-level coefficients (1 on the diagonal).
+func (cm *CorrelationMatrix) Matrix(legs []Leg) [][]float64 {
+	n := len(legs)
+	classes := make([]MarketClass, n)
+	for i, l := range legs {
+		classes[i] = classify(l.MarketID)
+	}
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			rho := cm.rho[pair(classes[i], classes[j])]
+			m[i][j] = rho
+			m[j][i] = rho
+		}
+	}
+	return m
+}
+
+func pair(a, b MarketClass) [2]MarketClass {
+	if a <= b {
+		return [2]MarketClass{a, b}
+	}
+	return [2]MarketClass{b, a}
+}
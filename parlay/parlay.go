@@ -0,0 +1,165 @@
+// Package parlay computes fair, correlation-adjusted prices for same-game
+// parlays (bet builders) assembled from outcomes of a single event's
+// OddsChange snapshot.
+//
+// Treating selections as independent and multiplying their odds
+// systematically overprices correlated combinations (e.g. "home win" and
+// "over 2.5 goals" tend to occur together more often than independence would
+// suggest). Combine de-vigs each leg's market, classifies the pairwise
+// correlation between legs and folds it into a Gaussian copula to produce a
+// combined probability, then reports the fair odds for the resulting ticket.
+package parlay
+
+import (
+	"errors"
+	"fmt"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+var (
+	// ErrMarketSuspended is returned when a selection references a market
+	// that is not currently accepting bets.
+	ErrMarketSuspended = errors.New("parlay: market suspended")
+	// ErrConflictingLines is returned when two selections share the same
+	// Market.ID but reference different LineID values, i.e. mutually
+	// exclusive lines of the same market (e.g. Over 1.5 and Over 2.5).
+	ErrConflictingLines = errors.New("parlay: conflicting lines in same market")
+	// ErrMarketNotFound is returned when a selection references a market
+	// that is not present in the supplied OddsChange snapshot.
+	ErrMarketNotFound = errors.New("parlay: market not found")
+	// ErrOutcomeNotFound is returned when a selection references an
+	// outcome that is not present within its market.
+	ErrOutcomeNotFound = errors.New("parlay: outcome not found")
+	// ErrNoOdds is returned when a selected outcome has no price quoted.
+	ErrNoOdds = errors.New("parlay: outcome has no odds")
+)
+
+// Selection identifies a single leg of a parlay ticket: a market line
+// (Market.ID + Market.LineID) and the outcome backed within it.
+type Selection struct {
+	MarketID int
+	LineID   int
+	Outcome  uof.URN
+}
+
+// Leg is a Selection resolved against an OddsChange snapshot, carrying the
+// de-vigged (fair) probability implied by its market.
+type Leg struct {
+	Selection
+	FairProbability float64
+}
+
+// Result is the outcome of combining a set of legs into a single ticket.
+type Result struct {
+	Legs        []Leg
+	Probability float64 // fair, correlation-adjusted probability of all legs winning
+	Odds        float64 // 1 / Probability
+}
+
+// Combine resolves sel against oc, de-vigs each leg's market, and returns the
+// fair combined price for the parlay under the given correlation matrix. A
+// nil matrix uses DefaultCorrelationMatrix.
+func Combine(oc *uof.OddsChange, sel []Selection, corr *CorrelationMatrix) (*Result, error) {
+	if corr == nil {
+		corr = DefaultCorrelationMatrix()
+	}
+	legs, err := resolveLegs(oc, sel)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkConflicts(legs); err != nil {
+		return nil, err
+	}
+
+	if len(legs) == 1 {
+		p := legs[0].FairProbability
+		return &Result{Legs: legs, Probability: p, Odds: 1 / p}, nil
+	}
+
+	rho := corr.Matrix(legs)
+	p, err := jointProbability(legs, rho)
+	if err != nil {
+		return nil, err
+	}
+	if p <= 0 {
+		return nil, fmt.Errorf("parlay: degenerate joint probability %v", p)
+	}
+	return &Result{Legs: legs, Probability: p, Odds: 1 / p}, nil
+}
+
+func resolveLegs(oc *uof.OddsChange, sel []Selection) ([]Leg, error) {
+	legs := make([]Leg, 0, len(sel))
+	for _, s := range sel {
+		m, err := findMarket(oc, s.MarketID, s.LineID)
+		if err != nil {
+			return nil, err
+		}
+		if m.Status != uof.MarketStatusActive {
+			return nil, fmt.Errorf("%w: market %d line %d", ErrMarketSuspended, s.MarketID, s.LineID)
+		}
+		p, err := fairProbability(m, s.Outcome)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, Leg{Selection: s, FairProbability: p})
+	}
+	return legs, nil
+}
+
+func findMarket(oc *uof.OddsChange, marketID, lineID int) (*uof.Market, error) {
+	if oc == nil || oc.Odds == nil {
+		return nil, ErrMarketNotFound
+	}
+	for i := range oc.Odds.Markets {
+		m := &oc.Odds.Markets[i]
+		if m.ID == marketID && m.LineID == lineID {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: market %d line %d", ErrMarketNotFound, marketID, lineID)
+}
+
+// fairProbability de-vigs m's outcomes (normalizing implied probabilities so
+// they sum to 1 across the market) and returns the share attributed to urn.
+func fairProbability(m *uof.Market, urn uof.URN) (float64, error) {
+	var overround float64
+	var target float64
+	var found bool
+	for _, o := range m.Outcomes {
+		if o.URN == urn {
+			found = true
+		}
+		if o.Odds == nil || *o.Odds <= 0 {
+			continue
+		}
+		p := 1 / *o.Odds
+		overround += p
+		if o.URN == urn {
+			target = p
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: outcome %v in market %d", ErrOutcomeNotFound, urn, m.ID)
+	}
+	if target == 0 {
+		return 0, fmt.Errorf("%w: outcome %v in market %d", ErrNoOdds, urn, m.ID)
+	}
+	if overround <= 0 {
+		return 0, ErrNoOdds
+	}
+	return target / overround, nil
+}
+
+// checkConflicts rejects tickets that select more than one line of the same
+// market, since such lines are mutually exclusive bets on the same market ID.
+func checkConflicts(legs []Leg) error {
+	lineByMarket := make(map[int]int, len(legs))
+	for _, l := range legs {
+		if existing, ok := lineByMarket[l.MarketID]; ok && existing != l.LineID {
+			return fmt.Errorf("%w: market %d lines %d and %d", ErrConflictingLines, l.MarketID, existing, l.LineID)
+		}
+		lineByMarket[l.MarketID] = l.LineID
+	}
+	return nil
+}
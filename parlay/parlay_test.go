@@ -0,0 +1,110 @@
+package parlay
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+func f64(v float64) *float64 { return &v }
+
+func oddsChange(markets ...uof.Market) *uof.OddsChange {
+	return &uof.OddsChange{EventURN: "sr:match:1", Odds: &uof.Odds{Markets: markets}}
+}
+
+// marketUnclassified builds a market with an ID outside marketClassByID, so
+// its correlation with any other market is always 0 under the default
+// matrix - useful for pinning the independent case.
+func marketUnclassified(id int, status uof.MarketStatus, oddsA, oddsB float64) uof.Market {
+	return uof.Market{ID: id, Status: status, Outcomes: []uof.Outcome{
+		{URN: "1", Odds: f64(oddsA)},
+		{URN: "2", Odds: f64(oddsB)},
+	}}
+}
+
+func TestCombineSingleLeg(t *testing.T) {
+	oc := oddsChange(marketUnclassified(501, uof.MarketStatusActive, 2.0, 2.0))
+	res, err := Combine(oc, []Selection{{MarketID: 501, Outcome: "1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(res.Probability-0.5) > 1e-9 {
+		t.Errorf("probability = %v, want 0.5", res.Probability)
+	}
+	if math.Abs(res.Odds-2.0) > 1e-9 {
+		t.Errorf("odds = %v, want 2.0", res.Odds)
+	}
+}
+
+func TestCombineIndependentLegs(t *testing.T) {
+	// Both markets fall outside the default correlation table, so rho = 0
+	// and the Gaussian copula should reduce to the independence case: the
+	// combined probability should track the plain product of fair
+	// probabilities (0.5 * 0.8 = 0.4).
+	oc := oddsChange(
+		marketUnclassified(501, uof.MarketStatusActive, 2.0, 2.0),
+		marketUnclassified(502, uof.MarketStatusActive, 1.25, 5.0),
+	)
+	sel := []Selection{
+		{MarketID: 501, Outcome: "1"},
+		{MarketID: 502, Outcome: "1"},
+	}
+	res, err := Combine(oc, sel, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = 0.4
+	if math.Abs(res.Probability-want) > 0.02 {
+		t.Errorf("probability = %v, want within 0.02 of %v", res.Probability, want)
+	}
+	wantOdds := 1 / want
+	if math.Abs(res.Odds-wantOdds) > 1 {
+		t.Errorf("odds = %v, want within 1 of %v", res.Odds, wantOdds)
+	}
+}
+
+func TestCombineDeVigsMarket(t *testing.T) {
+	// Odds of 1.8/1.8 imply an overround market (1/1.8 + 1/1.8 = 1.111); the
+	// de-vigged fair probability for either side should land back at 0.5.
+	oc := oddsChange(marketUnclassified(501, uof.MarketStatusActive, 1.8, 1.8))
+	res, err := Combine(oc, []Selection{{MarketID: 501, Outcome: "1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(res.Probability-0.5) > 1e-9 {
+		t.Errorf("probability = %v, want 0.5 after de-vigging", res.Probability)
+	}
+}
+
+func TestCombineRejectsSuspendedMarket(t *testing.T) {
+	oc := oddsChange(marketUnclassified(501, uof.MarketStatusSuspended, 2.0, 2.0))
+	_, err := Combine(oc, []Selection{{MarketID: 501, Outcome: "1"}}, nil)
+	if !errors.Is(err, ErrMarketSuspended) {
+		t.Fatalf("got %v, want ErrMarketSuspended", err)
+	}
+}
+
+func TestCombineRejectsConflictingLines(t *testing.T) {
+	oc := oddsChange(
+		uof.Market{ID: 501, LineID: 1, Status: uof.MarketStatusActive, Outcomes: []uof.Outcome{{URN: "1", Odds: f64(2.0)}}},
+		uof.Market{ID: 501, LineID: 2, Status: uof.MarketStatusActive, Outcomes: []uof.Outcome{{URN: "1", Odds: f64(1.5)}}},
+	)
+	sel := []Selection{
+		{MarketID: 501, LineID: 1, Outcome: "1"},
+		{MarketID: 501, LineID: 2, Outcome: "1"},
+	}
+	_, err := Combine(oc, sel, nil)
+	if !errors.Is(err, ErrConflictingLines) {
+		t.Fatalf("got %v, want ErrConflictingLines", err)
+	}
+}
+
+func TestCombineRejectsUnknownOutcome(t *testing.T) {
+	oc := oddsChange(marketUnclassified(501, uof.MarketStatusActive, 2.0, 2.0))
+	_, err := Combine(oc, []Selection{{MarketID: 501, Outcome: "9"}}, nil)
+	if !errors.Is(err, ErrOutcomeNotFound) {
+		t.Fatalf("got %v, want ErrOutcomeNotFound", err)
+	}
+}
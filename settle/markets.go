@@ -0,0 +1,267 @@
+package settle
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// Core market IDs seeded by this package. These are the Betradar market
+// descriptor IDs shared across most sports; settlement for anything beyond
+// this starter set should be added via Register.
+const (
+	marketMatchWinner      = 1  // 1x2: home win / draw / away win
+	marketDrawNoBet        = 8  // 1x2 with the stake returned on a draw
+	marketBothTeamsToScore = 10 // yes / no
+	marketHandicap         = 14 // handicap, specifier "hcp"
+	marketTotal            = 18 // over / under, specifier "total"
+)
+
+// Outcome ids as assigned by the Betradar market descriptors for the core
+// markets above. Match Winner, Draw No Bet and Handicap share the same
+// 1 (home) / 2 (draw) / 3 (away) id space; Total and Both Teams To Score
+// have their own descriptor-specific ids.
+const (
+	outcomeHome = "1"
+	outcomeDraw = "2"
+	outcomeAway = "3"
+
+	outcomeOver  = "12"
+	outcomeUnder = "13"
+
+	outcomeBTTSYes = "74"
+	outcomeBTTSNo  = "76"
+)
+
+func scores(status uof.SportEventStatus) (home, away int, err error) {
+	if status.HomeScore == nil || status.AwayScore == nil {
+		return 0, 0, fmt.Errorf("settle: final score not available")
+	}
+	return *status.HomeScore, *status.AwayScore, nil
+}
+
+func settleMatchWinner(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+	home, away, err := scores(status)
+	if err != nil {
+		return nil, err
+	}
+	winner := outcomeDraw
+	switch {
+	case home > away:
+		winner = outcomeHome
+	case away > home:
+		winner = outcomeAway
+	}
+	return settleWinnerTakesAll(m, winner), nil
+}
+
+func settleDrawNoBet(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+	home, away, err := scores(status)
+	if err != nil {
+		return nil, err
+	}
+	if home == away {
+		return settleVoidAll(m), nil
+	}
+	winner := outcomeAway
+	if home > away {
+		winner = outcomeHome
+	}
+	return settleWinnerTakesAll(m, winner), nil
+}
+
+func settleBothTeamsToScore(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+	home, away, err := scores(status)
+	if err != nil {
+		return nil, err
+	}
+	winner := outcomeBTTSNo
+	if home > 0 && away > 0 {
+		winner = outcomeBTTSYes
+	}
+	return settleWinnerTakesAll(m, winner), nil
+}
+
+// settleTotal settles an over/under market using its "total" specifier
+// (e.g. "total=2.5"). Quarter lines (e.g. "total=2.25", "total=2.75") are
+// split into the two adjacent half-lines per the standard Asian-total
+// convention, which can produce a HalfWon/HalfLost split rather than a full
+// Won/Lost/Void result.
+func settleTotal(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+	home, away, err := scores(status)
+	if err != nil {
+		return nil, err
+	}
+	line, err := specifierFloat(m, "total")
+	if err != nil {
+		return nil, err
+	}
+	total := float64(home + away)
+	over := settleAgainstLine(total, line)
+
+	out := make([]OutcomeSettlement, len(m.Outcomes))
+	for i, o := range m.Outcomes {
+		switch string(o.URN) {
+		case outcomeOver:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: over}
+		case outcomeUnder:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: mirrorResult(over)}
+		default:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: Void}
+		}
+	}
+	return out, nil
+}
+
+// settleHandicap settles a handicap market using its "hcp" specifier,
+// applied to the home team's score (the Betradar convention for this
+// specifier's sign). Quarter lines (e.g. "hcp=-0.25") are split into the two
+// adjacent half-lines per the standard Asian-handicap convention, which can
+// produce a HalfWon/HalfLost split rather than a full Won/Lost/Void result.
+func settleHandicap(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+	home, away, err := scores(status)
+	if err != nil {
+		return nil, err
+	}
+	hcp, err := specifierFloat(m, "hcp")
+	if err != nil {
+		return nil, err
+	}
+	diff := float64(home) - float64(away)
+	home2 := settleAgainstLine(diff, -hcp)
+
+	out := make([]OutcomeSettlement, len(m.Outcomes))
+	for i, o := range m.Outcomes {
+		switch string(o.URN) {
+		case outcomeHome:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: home2}
+		case outcomeAway:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: mirrorResult(home2)}
+		default:
+			out[i] = OutcomeSettlement{URN: o.URN, Result: Void}
+		}
+	}
+	return out, nil
+}
+
+// settleAgainstLine settles a "favourite" side of a line-based market: won
+// if diff clears line, lost if it falls short, void on an exact push. When
+// line is a quarter line (its fractional part is an odd multiple of 0.25,
+// e.g. 2.25 or -0.75), the stake is split across the two adjacent half-lines
+// and the results combined, which can yield HalfWon/HalfLost.
+func settleAgainstLine(diff, line float64) Result {
+	if !isQuarterLine(line) {
+		return compareToLine(diff, line)
+	}
+	return combineHalves(
+		compareToLine(diff, line-0.25),
+		compareToLine(diff, line+0.25),
+	)
+}
+
+func compareToLine(diff, line float64) Result {
+	switch {
+	case diff > line:
+		return Won
+	case diff < line:
+		return Lost
+	default:
+		return Void
+	}
+}
+
+// isQuarterLine reports whether line sits exactly halfway between two
+// adjacent half-lines (i.e. line*4 is an odd integer), the case that needs
+// splitting into two separately-settled half stakes.
+func isQuarterLine(line float64) bool {
+	quarters := line * 4
+	rounded := math.Round(quarters)
+	if math.Abs(quarters-rounded) > 1e-9 {
+		return false
+	}
+	return math.Mod(rounded, 2) != 0
+}
+
+// combineHalves merges the settlement of a quarter line's two equally
+// staked half-lines. Matching results pass through unchanged; a push on one
+// half combined with a win or loss on the other becomes a half win or half
+// loss respectively.
+func combineHalves(a, b Result) Result {
+	switch {
+	case a == b:
+		return a
+	case a == Void:
+		return halfOf(b)
+	case b == Void:
+		return halfOf(a)
+	default:
+		// Won vs Lost can't occur for two lines 0.5 apart, but don't
+		// silently return a result skewed one way if it ever does.
+		return Void
+	}
+}
+
+func halfOf(r Result) Result {
+	switch r {
+	case Won:
+		return HalfWon
+	case Lost:
+		return HalfLost
+	default:
+		return r
+	}
+}
+
+// mirrorResult returns the settlement of the opposite side of a two-outcome
+// market (e.g. Under given Over's result, or Away given Home's).
+func mirrorResult(r Result) Result {
+	switch r {
+	case Won:
+		return Lost
+	case Lost:
+		return Won
+	case HalfWon:
+		return HalfLost
+	case HalfLost:
+		return HalfWon
+	default:
+		return r
+	}
+}
+
+func settleWinnerTakesAll(m uof.Market, winnerURN string) []OutcomeSettlement {
+	out := make([]OutcomeSettlement, len(m.Outcomes))
+	for i, o := range m.Outcomes {
+		out[i] = OutcomeSettlement{URN: o.URN, Result: resultFor(string(o.URN) == winnerURN)}
+	}
+	return out
+}
+
+func settleVoidAll(m uof.Market) []OutcomeSettlement {
+	out := make([]OutcomeSettlement, len(m.Outcomes))
+	for i, o := range m.Outcomes {
+		out[i] = OutcomeSettlement{URN: o.URN, Result: Void}
+	}
+	return out
+}
+
+func resultFor(won bool) Result {
+	if won {
+		return Won
+	}
+	return Lost
+}
+
+func specifierFloat(m uof.Market, key string) (float64, error) {
+	raw, ok := m.Specifiers[key]
+	if !ok {
+		return 0, fmt.Errorf("settle: market %d missing %q specifier", m.ID, key)
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("settle: market %d specifier %q: %w", m.ID, key, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,151 @@
+package settle
+
+import (
+	"testing"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+func intPtr(v int) *int { return &v }
+
+func status(home, away int) uof.SportEventStatus {
+	return uof.SportEventStatus{HomeScore: intPtr(home), AwayScore: intPtr(away)}
+}
+
+func outcome(urn string) uof.Outcome { return uof.Outcome{URN: uof.URN(urn)} }
+
+func resultOf(t *testing.T, out []OutcomeSettlement, urn string) Result {
+	t.Helper()
+	for _, o := range out {
+		if string(o.URN) == urn {
+			return o.Result
+		}
+	}
+	t.Fatalf("no outcome %q in settlement", urn)
+	return Void
+}
+
+func TestSettleMatchWinner(t *testing.T) {
+	m := uof.Market{ID: marketMatchWinner, Outcomes: []uof.Outcome{outcome(outcomeHome), outcome(outcomeDraw), outcome(outcomeAway)}}
+
+	out, err := settleMatchWinner(m, status(2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeHome); got != Won {
+		t.Errorf("home: got %s, want won", got)
+	}
+	if got := resultOf(t, out, outcomeAway); got != Lost {
+		t.Errorf("away: got %s, want lost", got)
+	}
+
+	out, err = settleMatchWinner(m, status(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeDraw); got != Won {
+		t.Errorf("draw: got %s, want won", got)
+	}
+}
+
+func TestSettleTotalWholeLine(t *testing.T) {
+	m := uof.Market{ID: marketTotal, Specifiers: map[string]string{"total": "2.5"},
+		Outcomes: []uof.Outcome{outcome(outcomeOver), outcome(outcomeUnder)}}
+
+	out, err := settleTotal(m, status(2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeOver); got != Won {
+		t.Errorf("over: got %s, want won", got)
+	}
+	if got := resultOf(t, out, outcomeUnder); got != Lost {
+		t.Errorf("under: got %s, want lost", got)
+	}
+}
+
+func TestSettleTotalQuarterLine(t *testing.T) {
+	// total=2.25 splits into 2.0 and 2.5. With 2 goals: push on 2.0, loss on
+	// 2.5, so Over is a half loss and Under a half win.
+	m := uof.Market{ID: marketTotal, Specifiers: map[string]string{"total": "2.25"},
+		Outcomes: []uof.Outcome{outcome(outcomeOver), outcome(outcomeUnder)}}
+
+	out, err := settleTotal(m, status(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeOver); got != HalfLost {
+		t.Errorf("over: got %s, want half_lost", got)
+	}
+	if got := resultOf(t, out, outcomeUnder); got != HalfWon {
+		t.Errorf("under: got %s, want half_won", got)
+	}
+}
+
+func TestSettleHandicapQuarterLine(t *testing.T) {
+	// hcp=-0.25 splits into 0 and -0.5 for the home side. A 1-1 draw pushes
+	// on the 0 line and loses on the -0.5 line: half loss for home, half win
+	// for away.
+	m := uof.Market{ID: marketHandicap, Specifiers: map[string]string{"hcp": "-0.25"},
+		Outcomes: []uof.Outcome{outcome(outcomeHome), outcome(outcomeAway)}}
+
+	out, err := settleHandicap(m, status(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeHome); got != HalfLost {
+		t.Errorf("home: got %s, want half_lost", got)
+	}
+	if got := resultOf(t, out, outcomeAway); got != HalfWon {
+		t.Errorf("away: got %s, want half_won", got)
+	}
+}
+
+func TestSettleHandicapWholeLine(t *testing.T) {
+	// hcp=-1 requires home to win by more than one goal to cover.
+	m := uof.Market{ID: marketHandicap, Specifiers: map[string]string{"hcp": "-1"},
+		Outcomes: []uof.Outcome{outcome(outcomeHome), outcome(outcomeAway)}}
+
+	out, err := settleHandicap(m, status(3, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultOf(t, out, outcomeHome); got != Won {
+		t.Errorf("home: got %s, want won", got)
+	}
+	if got := resultOf(t, out, outcomeAway); got != Lost {
+		t.Errorf("away: got %s, want lost", got)
+	}
+}
+
+func TestSettleMissingScore(t *testing.T) {
+	m := uof.Market{ID: marketMatchWinner, Outcomes: []uof.Outcome{outcome(outcomeHome)}}
+	if _, err := settleMatchWinner(m, uof.SportEventStatus{}); err == nil {
+		t.Fatal("expected error for missing score")
+	}
+}
+
+func TestSettleRegistryAndRegister(t *testing.T) {
+	if !Registered(marketMatchWinner) {
+		t.Fatal("expected match winner to be registered")
+	}
+	if Registered(9999) {
+		t.Fatal("did not expect market 9999 to be registered")
+	}
+
+	Register(9999, func(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error) {
+		return settleWinnerTakesAll(m, outcomeHome), nil
+	})
+	if !Registered(9999) {
+		t.Fatal("expected market 9999 to be registered after Register")
+	}
+
+	markets := []uof.Market{{ID: marketMatchWinner, Outcomes: []uof.Outcome{outcome(outcomeHome), outcome(outcomeAway)}}}
+	settlements, err := Settle(markets, status(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settlements) != 1 {
+		t.Fatalf("got %d settlements, want 1", len(settlements))
+	}
+}
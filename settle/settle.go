@@ -0,0 +1,111 @@
+// Package settle computes self-settlement results for markets from an
+// event's final SportEventStatus, independent of Betradar's own
+// bet_settlement feed. Operators use it to cross-check (or settle ahead of)
+// the feed's own settlement messages, which can lag the match result by
+// minutes.
+package settle
+
+import (
+	"fmt"
+	"sync"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// Result is the outcome of a single settled selection.
+type Result int
+
+const (
+	Lost Result = iota
+	Won
+	Void
+	HalfWon
+	HalfLost
+)
+
+func (r Result) String() string {
+	switch r {
+	case Won:
+		return "won"
+	case Lost:
+		return "lost"
+	case Void:
+		return "void"
+	case HalfWon:
+		return "half_won"
+	case HalfLost:
+		return "half_lost"
+	default:
+		return "unknown"
+	}
+}
+
+// OutcomeSettlement is the computed result for a single outcome.
+type OutcomeSettlement struct {
+	URN    uof.URN
+	Result Result
+}
+
+// MarketSettlement is the computed result for every outcome of a single
+// market line.
+type MarketSettlement struct {
+	MarketID int
+	LineID   int
+	Outcomes []OutcomeSettlement
+}
+
+// SettleFunc computes the settlement result for every outcome of m, given
+// the event's terminal status. It returns an error if status doesn't carry
+// enough information to settle m (e.g. missing scores).
+type SettleFunc func(m uof.Market, status uof.SportEventStatus) ([]OutcomeSettlement, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[int]SettleFunc{
+		marketMatchWinner:      settleMatchWinner,
+		marketDrawNoBet:        settleDrawNoBet,
+		marketTotal:            settleTotal,
+		marketHandicap:         settleHandicap,
+		marketBothTeamsToScore: settleBothTeamsToScore,
+	}
+)
+
+// Register adds or replaces the settlement function used for market id. It
+// lets a caller extend the registry with markets this package doesn't ship,
+// or override the shipped behaviour for one it gets wrong for their sport.
+// It is safe to call concurrently with Settle and with itself.
+func Register(id int, fn SettleFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[id] = fn
+}
+
+// Settle computes settlement results for every market in markets, using
+// status as the terminal event outcome. Markets whose ID has no registered
+// SettleFunc are skipped; callers that need to know about skips should
+// check len(result) against len(markets) or consult Registered.
+func Settle(markets []uof.Market, status uof.SportEventStatus) ([]MarketSettlement, error) {
+	result := make([]MarketSettlement, 0, len(markets))
+	for _, m := range markets {
+		registryMu.RLock()
+		fn, ok := registry[m.ID]
+		registryMu.RUnlock()
+		if !ok {
+			continue
+		}
+		outcomes, err := fn(m, status)
+		if err != nil {
+			return nil, fmt.Errorf("settle: market %d line %d: %w", m.ID, m.LineID, err)
+		}
+		result = append(result, MarketSettlement{MarketID: m.ID, LineID: m.LineID, Outcomes: outcomes})
+	}
+	return result, nil
+}
+
+// Registered reports whether a SettleFunc is registered for market id.
+func Registered(id int) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := registry[id]
+	return ok
+}
@@ -0,0 +1,69 @@
+package sink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// Batcher accumulates OddsChange messages and flushes them together, either
+// once Size messages have queued up or Interval has elapsed since the first
+// unflushed message, whichever comes first. PubSubSink and RabbitMQSink use
+// it to group messages; each of them still delivers the messages of a batch
+// one at a time.
+type Batcher struct {
+	Size     int
+	Interval time.Duration
+	Flush    func(ctx context.Context, batch []*uof.OddsChange) error
+	// Ctx bounds interval-triggered flushes, which fire on their own timer
+	// rather than as part of any single Add call and so can't reuse an
+	// Add caller's (likely short-lived, per-request) context. Defaults to
+	// context.Background() if nil.
+	Ctx context.Context
+
+	mu      sync.Mutex
+	pending []*uof.OddsChange
+	timer   *time.Timer
+}
+
+// Add queues oc, flushing immediately if the batch reached Size. If this is
+// the first message in a new batch, it also arms the Interval timer, which
+// flushes using b.Ctx rather than ctx.
+func (b *Batcher) Add(ctx context.Context, oc *uof.OddsChange) error {
+	b.mu.Lock()
+	b.pending = append(b.pending, oc)
+	full := len(b.pending) >= b.Size
+	if len(b.pending) == 1 && b.Interval > 0 {
+		flushCtx := b.Ctx
+		if flushCtx == nil {
+			flushCtx = context.Background()
+		}
+		b.timer = time.AfterFunc(b.Interval, func() { _ = b.FlushNow(flushCtx) })
+	}
+	b.mu.Unlock()
+
+	if full {
+		return b.FlushNow(ctx)
+	}
+	return nil
+}
+
+// FlushNow flushes any pending messages immediately, regardless of Size or
+// Interval. It is safe to call concurrently with Add.
+func (b *Batcher) FlushNow(ctx context.Context) error {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	return b.Flush(ctx, batch)
+}
@@ -0,0 +1,24 @@
+package sink
+
+import "strings"
+
+// joinErrors combines errs into a single error, or returns nil if errs is
+// empty. It exists so Fanout doesn't need to pick an arbitrary first error
+// out of several sink failures.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return multiError(strings.Join(msgs, "; "))
+}
+
+type multiError string
+
+func (e multiError) Error() string { return string(e) }
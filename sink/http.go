@@ -0,0 +1,73 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// HTTPSink delivers each OddsChange as a JSON-encoded POST request to a
+// webhook URL.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+	retry  RetryConfig
+	m      metrics
+}
+
+// NewHTTPSink builds a sink that POSTs to url using client (http.DefaultClient
+// if nil), retrying failed deliveries per retry (DefaultRetryConfig if the
+// zero value).
+func NewHTTPSink(url string, client *http.Client, retry RetryConfig) *HTTPSink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if retry == (RetryConfig{}) {
+		retry = DefaultRetryConfig
+	}
+	return &HTTPSink{url: url, client: client, retry: retry}
+}
+
+// Publish POSTs oc as JSON to the sink's URL. A non-2xx response is treated
+// as a failed delivery and retried.
+func (s *HTTPSink) Publish(ctx context.Context, oc *uof.OddsChange) error {
+	body, err := json.Marshal(oc)
+	if err != nil {
+		s.m.addDropped()
+		return fmt.Errorf("sink: marshal odds change: %w", err)
+	}
+
+	err = withRetry(ctx, s.retry, &s.m, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("sink: webhook %s returned status %d", s.url, resp.StatusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		s.m.addDropped()
+		return err
+	}
+	s.m.addPublished()
+	return nil
+}
+
+// Close is a no-op; HTTPSink holds no resources beyond its http.Client.
+func (s *HTTPSink) Close() error { return nil }
+
+// Metrics returns a snapshot of the sink's delivery counters.
+func (s *HTTPSink) Metrics() Metrics { return s.m.snapshot() }
@@ -0,0 +1,89 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// PubSubSink publishes OddsChange messages to a Google Cloud Pub/Sub topic.
+// Messages are grouped by a Batcher; when a batch is flushed, each message is
+// published and its result awaited before the next one is published.
+type PubSubSink struct {
+	topic   *pubsub.Topic
+	retry   RetryConfig
+	batcher *Batcher
+	m       metrics
+}
+
+// NewPubSubSink builds a sink publishing to topic. ctx is used for
+// interval-triggered flushes, which aren't tied to any single Publish call;
+// cancelling it affects only those flushes and does not stop the sink (Close
+// does).
+// batchSize/batchInterval configure the underlying Batcher (see
+// Batcher.Size/Interval); pass 1 and 0 to publish every message immediately.
+// A zero RetryConfig uses DefaultRetryConfig.
+func NewPubSubSink(ctx context.Context, topic *pubsub.Topic, batchSize int, batchInterval time.Duration, retry RetryConfig) *PubSubSink {
+	if retry == (RetryConfig{}) {
+		retry = DefaultRetryConfig
+	}
+	s := &PubSubSink{topic: topic, retry: retry}
+	s.batcher = &Batcher{Size: batchSize, Interval: batchInterval, Flush: s.flush, Ctx: ctx}
+	return s
+}
+
+// Publish queues oc for delivery via the sink's Batcher.
+func (s *PubSubSink) Publish(ctx context.Context, oc *uof.OddsChange) error {
+	return s.batcher.Add(ctx, oc)
+}
+
+func (s *PubSubSink) flush(ctx context.Context, batch []*uof.OddsChange) error {
+	var firstErr error
+	for _, oc := range batch {
+		body, err := json.Marshal(oc)
+		if err != nil {
+			s.m.addDropped()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		attrs := map[string]string{
+			"eventURN": string(oc.EventURN),
+			"product":  fmt.Sprintf("%d", oc.Product),
+		}
+
+		// Each retry attempt republishes the message: a pubsub.PublishResult
+		// is a one-shot future tied to the Publish call that created it, so
+		// re-awaiting a stale result would just return the same cached error.
+		err = withRetry(ctx, s.retry, &s.m, func(ctx context.Context) error {
+			result := s.topic.Publish(ctx, &pubsub.Message{Data: body, Attributes: attrs})
+			_, err := result.Get(ctx)
+			return err
+		})
+		if err != nil {
+			s.m.addDropped()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		s.m.addPublished()
+	}
+	return firstErr
+}
+
+// Close flushes any pending batch and stops the underlying Pub/Sub topic.
+func (s *PubSubSink) Close() error {
+	err := s.batcher.FlushNow(context.Background())
+	s.topic.Stop()
+	return err
+}
+
+// Metrics returns a snapshot of the sink's delivery counters.
+func (s *PubSubSink) Metrics() Metrics { return s.m.snapshot() }
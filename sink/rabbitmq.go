@@ -0,0 +1,89 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// RabbitMQSink publishes OddsChange messages to a RabbitMQ exchange as JSON
+// bodies, one message per OddsChange (RabbitMQ has no native batch-publish,
+// so Batcher here only governs how often Publish blocks on the channel
+// rather than reducing request count).
+type RabbitMQSink struct {
+	channel  *amqp.Channel
+	exchange string
+	routing  func(oc *uof.OddsChange) string
+	retry    RetryConfig
+	batcher  *Batcher
+	m        metrics
+}
+
+// NewRabbitMQSink builds a sink publishing to exchange over channel. ctx is
+// used for interval-triggered flushes, which aren't tied to any single
+// Publish call; cancelling it affects only those flushes and does not stop
+// the sink (Close does). routing derives the routing key for each message;
+// pass nil to use the empty routing key. A zero RetryConfig uses DefaultRetryConfig.
+func NewRabbitMQSink(ctx context.Context, channel *amqp.Channel, exchange string, routing func(oc *uof.OddsChange) string, batchSize int, batchInterval time.Duration, retry RetryConfig) *RabbitMQSink {
+	if routing == nil {
+		routing = func(*uof.OddsChange) string { return "" }
+	}
+	if retry == (RetryConfig{}) {
+		retry = DefaultRetryConfig
+	}
+	s := &RabbitMQSink{channel: channel, exchange: exchange, routing: routing, retry: retry}
+	s.batcher = &Batcher{Size: batchSize, Interval: batchInterval, Flush: s.flush, Ctx: ctx}
+	return s
+}
+
+// Publish queues oc for delivery via the sink's Batcher.
+func (s *RabbitMQSink) Publish(ctx context.Context, oc *uof.OddsChange) error {
+	return s.batcher.Add(ctx, oc)
+}
+
+func (s *RabbitMQSink) flush(ctx context.Context, batch []*uof.OddsChange) error {
+	var firstErr error
+	for _, oc := range batch {
+		body, err := json.Marshal(oc)
+		if err != nil {
+			s.m.addDropped()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		key := s.routing(oc)
+		err = withRetry(ctx, s.retry, &s.m, func(ctx context.Context) error {
+			return s.channel.PublishWithContext(ctx, s.exchange, key, false, false, amqp.Publishing{
+				ContentType: "application/json",
+				Body:        body,
+				Timestamp:   time.UnixMilli(oc.Timestamp),
+			})
+		})
+		if err != nil {
+			s.m.addDropped()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		s.m.addPublished()
+	}
+	return firstErr
+}
+
+// Close flushes any pending batch and closes the underlying channel.
+func (s *RabbitMQSink) Close() error {
+	err := s.batcher.FlushNow(context.Background())
+	if cerr := s.channel.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Metrics returns a snapshot of the sink's delivery counters.
+func (s *RabbitMQSink) Metrics() Metrics { return s.m.snapshot() }
@@ -0,0 +1,205 @@
+// Package sink lets the SDK's inbound feed fan out to one or more outbound
+// delivery mechanisms - Google Cloud Pub/Sub, RabbitMQ, or a plain HTTP POST
+// webhook - instead of requiring every consumer to embed the AMQP client
+// directly. Each Sink can be filtered independently and wraps its own retry
+// and metrics (and, for Pub/Sub and RabbitMQ, batching) so a caller only has
+// to call Publish.
+package sink
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+// Sink delivers OddsChange messages to some downstream system.
+type Sink interface {
+	Publish(ctx context.Context, oc *uof.OddsChange) error
+	Close() error
+}
+
+// Metrics is a snapshot of a sink's delivery counters. It is safe to read
+// concurrently with a sink's own goroutines updating it.
+type Metrics struct {
+	Published uint64
+	Dropped   uint64
+	Retried   uint64
+}
+
+type metrics struct {
+	mu sync.Mutex
+	m  Metrics
+}
+
+func (m *metrics) addPublished() { m.mu.Lock(); m.m.Published++; m.mu.Unlock() }
+func (m *metrics) addDropped()   { m.mu.Lock(); m.m.Dropped++; m.mu.Unlock() }
+func (m *metrics) addRetried()   { m.mu.Lock(); m.m.Retried++; m.mu.Unlock() }
+
+func (m *metrics) snapshot() Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m
+}
+
+// Filter decides which OddsChange messages a sink receives. A zero value
+// Filter passes everything through. Empty allow/deny lists are not
+// restrictive; Producers and EventURNPrefixes only restrict when non-empty,
+// and deny lists are checked before allow lists.
+type Filter struct {
+	Producers        []uof.Producer
+	EventURNPrefixes []string
+	MarketIDsAllow   []int
+	MarketIDsDeny    []int
+}
+
+// Match reports whether oc passes f. A message passes if it matches the
+// Producers/EventURNPrefixes restrictions (when set) and, when a market
+// allow or deny list is set, has at least one market that is not denied and
+// (when an allow list is set) is allowed. A message with no Odds is not
+// checked against the market lists and passes them.
+func (f Filter) Match(oc *uof.OddsChange) bool {
+	if len(f.Producers) > 0 && !containsProducer(f.Producers, oc.Product) {
+		return false
+	}
+	if len(f.EventURNPrefixes) > 0 && !hasAnyPrefix(string(oc.EventURN), f.EventURNPrefixes) {
+		return false
+	}
+	if oc.Odds == nil || (len(f.MarketIDsAllow) == 0 && len(f.MarketIDsDeny) == 0) {
+		return true
+	}
+	for _, m := range oc.Odds.Markets {
+		if containsInt(f.MarketIDsDeny, m.ID) {
+			continue
+		}
+		if len(f.MarketIDsAllow) == 0 || containsInt(f.MarketIDsAllow, m.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsProducer(ps []uof.Producer, p uof.Producer) bool {
+	for _, x := range ps {
+		if x == p {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(xs []int, x int) bool {
+	for _, v := range xs {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryConfig controls the exponential backoff applied around a sink's
+// underlying delivery call.
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryConfig is used by sinks that don't specify their own.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 5,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// withRetry calls fn, retrying with exponential backoff (doubling from
+// cfg.BaseDelay, capped at cfg.MaxDelay), for at most cfg.MaxAttempts calls
+// in total. Each retry is counted in m's Retried counter.
+func withRetry(ctx context.Context, cfg RetryConfig, m *metrics, fn func(ctx context.Context) error) error {
+	delay := cfg.BaseDelay
+	var err error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			m.addRetried()
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			delay *= 2
+			if delay > cfg.MaxDelay {
+				delay = cfg.MaxDelay
+			}
+		}
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Fanout publishes to every sink it holds, applying each sink's filter
+// before delivery. It satisfies Sink itself so it can be nested or used
+// wherever a single sink is expected.
+type Fanout struct {
+	entries []fanoutEntry
+}
+
+type fanoutEntry struct {
+	sink   Sink
+	filter Filter
+}
+
+// NewFanout builds a Fanout over sinks, each gated by its matching filter in
+// filters (by index; a missing filter defaults to the zero value, which
+// passes everything).
+func NewFanout(sinks []Sink, filters []Filter) *Fanout {
+	f := &Fanout{entries: make([]fanoutEntry, len(sinks))}
+	for i, s := range sinks {
+		var filter Filter
+		if i < len(filters) {
+			filter = filters[i]
+		}
+		f.entries[i] = fanoutEntry{sink: s, filter: filter}
+	}
+	return f
+}
+
+// Publish delivers oc to every sink whose filter matches. It collects and
+// joins errors from all sinks rather than stopping at the first failure, so
+// one failing sink doesn't prevent delivery to the others.
+func (f *Fanout) Publish(ctx context.Context, oc *uof.OddsChange) error {
+	var errs []error
+	for _, e := range f.entries {
+		if !e.filter.Match(oc) {
+			continue
+		}
+		if err := e.sink.Publish(ctx, oc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// Close closes every sink, returning the first error encountered (after
+// attempting to close all of them).
+func (f *Fanout) Close() error {
+	var first error
+	for _, e := range f.entries {
+		if err := e.sink.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
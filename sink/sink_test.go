@@ -0,0 +1,189 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	uof "github.com/mikha-dev/go-uof-sdk"
+)
+
+type fakeSink struct {
+	mu        sync.Mutex
+	published []*uof.OddsChange
+	failTimes int
+	closed    bool
+}
+
+func (f *fakeSink) Publish(ctx context.Context, oc *uof.OddsChange) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failTimes > 0 {
+		f.failTimes--
+		return errors.New("fake: publish failed")
+	}
+	f.published = append(f.published, oc)
+	return nil
+}
+
+func (f *fakeSink) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeSink) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.published)
+}
+
+func TestFilterMatch(t *testing.T) {
+	oc := &uof.OddsChange{
+		EventURN: "sr:match:1",
+		Product:  1,
+		Odds:     &uof.Odds{Markets: []uof.Market{{ID: 1}, {ID: 18}}},
+	}
+
+	cases := []struct {
+		name string
+		f    Filter
+		want bool
+	}{
+		{"zero value passes", Filter{}, true},
+		{"producer match", Filter{Producers: []uof.Producer{1}}, true},
+		{"producer mismatch", Filter{Producers: []uof.Producer{3}}, false},
+		{"prefix match", Filter{EventURNPrefixes: []string{"sr:"}}, true},
+		{"prefix mismatch", Filter{EventURNPrefixes: []string{"vf:"}}, false},
+		{"allow list hit", Filter{MarketIDsAllow: []int{18}}, true},
+		{"allow list miss", Filter{MarketIDsAllow: []int{99}}, false},
+		{"deny list blocks all", Filter{MarketIDsDeny: []int{1, 18}}, false},
+		{"deny one, allow remaining", Filter{MarketIDsDeny: []int{1}}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.f.Match(oc); got != c.want {
+				t.Errorf("Match() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFanoutPublishAppliesFilterAndCollectsErrors(t *testing.T) {
+	oc := &uof.OddsChange{EventURN: "sr:match:1", Odds: &uof.Odds{Markets: []uof.Market{{ID: 1}}}}
+
+	allowed := &fakeSink{}
+	denied := &fakeSink{}
+	failing := &fakeSink{failTimes: 1}
+
+	f := NewFanout(
+		[]Sink{allowed, denied, failing},
+		[]Filter{{}, {MarketIDsAllow: []int{999}}, {}},
+	)
+
+	err := f.Publish(context.Background(), oc)
+	if err == nil {
+		t.Fatal("expected an error from the failing sink")
+	}
+	if allowed.count() != 1 {
+		t.Errorf("allowed sink got %d messages, want 1", allowed.count())
+	}
+	if denied.count() != 0 {
+		t.Errorf("denied sink got %d messages, want 0 (filtered out)", denied.count())
+	}
+	if failing.count() != 0 {
+		t.Errorf("failing sink got %d messages, want 0", failing.count())
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !allowed.closed || !denied.closed || !failing.closed {
+		t.Error("Close should close every sink")
+	}
+}
+
+func TestWithRetryRetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	cfg := RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	var m metrics
+
+	err := withRetry(context.Background(), cfg, &m, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if got := m.snapshot().Retried; got != 2 {
+		t.Errorf("retried = %d, want 2", got)
+	}
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	cfg := RetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	var m metrics
+
+	err := withRetry(context.Background(), cfg, &m, func(ctx context.Context) error {
+		calls++
+		return errors.New("always fails")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestBatcherFlushesOnSize(t *testing.T) {
+	var flushed [][]*uof.OddsChange
+	b := &Batcher{Size: 2, Flush: func(ctx context.Context, batch []*uof.OddsChange) error {
+		flushed = append(flushed, batch)
+		return nil
+	}}
+
+	ctx := context.Background()
+	if err := b.Add(ctx, &uof.OddsChange{Timestamp: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(flushed) != 0 {
+		t.Fatalf("expected no flush before Size reached, got %d", len(flushed))
+	}
+	if err := b.Add(ctx, &uof.OddsChange{Timestamp: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if len(flushed) != 1 || len(flushed[0]) != 2 {
+		t.Fatalf("expected one flush of 2 messages, got %+v", flushed)
+	}
+}
+
+func TestBatcherFlushesOnInterval(t *testing.T) {
+	flushed := make(chan []*uof.OddsChange, 1)
+	b := &Batcher{Size: 100, Interval: 10 * time.Millisecond, Ctx: context.Background(),
+		Flush: func(ctx context.Context, batch []*uof.OddsChange) error {
+			flushed <- batch
+			return nil
+		}}
+
+	if err := b.Add(context.Background(), &uof.OddsChange{Timestamp: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case batch := <-flushed:
+		if len(batch) != 1 {
+			t.Errorf("got %d messages, want 1", len(batch))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("interval flush never fired")
+	}
+}
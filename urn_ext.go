@@ -0,0 +1,71 @@
+package uof
+
+import "strings"
+
+// URN prefixes, the first colon-separated segment of an EventURN.
+// Reference: https://docs.betradar.com/display/BD/UOF+-+URN
+const (
+	PrefixSR  = "sr"  // Sportradar
+	PrefixVF  = "vf"  // virtual football
+	PrefixWNS = "wns" // virtual "We Need Sport" events
+)
+
+// URN event types, the second colon-separated segment of an EventURN.
+const (
+	TypeMatch            = "match"
+	TypeSeason           = "season"
+	TypeStage            = "stage"
+	TypeTournament       = "tournament"
+	TypeCompetitor       = "competitor"
+	TypeSimpleTournament = "simple_tournament"
+	TypeOutright         = "outright"
+)
+
+// Prefix returns the URN's prefix segment, e.g. "sr" for "sr:match:12345".
+// It returns "" if u doesn't have at least three colon-separated segments.
+func (u URN) Prefix() string {
+	prefix, _, ok := splitURN(u)
+	if !ok {
+		return ""
+	}
+	return prefix
+}
+
+// Type returns the URN's type segment, e.g. "match" for "sr:match:12345".
+// It returns "" if u doesn't have at least three colon-separated segments.
+func (u URN) Type() string {
+	_, typ, ok := splitURN(u)
+	if !ok {
+		return ""
+	}
+	return typ
+}
+
+// IsVirtual reports whether u identifies a virtual (simulated) event, as
+// opposed to a real-world match or tournament.
+func (u URN) IsVirtual() bool {
+	switch u.Prefix() {
+	case PrefixVF, PrefixWNS:
+		return true
+	default:
+		return false
+	}
+}
+
+// splitURN splits a "prefix:type:id" URN into its prefix and type segments.
+// ok is false if u has fewer than three colon-separated parts.
+func splitURN(u URN) (prefix, typ string, ok bool) {
+	parts := strings.SplitN(string(u), ":", 3)
+	if len(parts) < 3 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// IsPrematchOnly reports whether o was generated by the prematch-only
+// producer (Betradar's "Ctrl" producer, id 3), as opposed to a producer that
+// also serves live odds. Downstream consumers use this to route prematch
+// markets differently without hardcoding the producer id themselves.
+func (o *OddsChange) IsPrematchOnly() bool {
+	return o.Product == 3
+}
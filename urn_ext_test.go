@@ -0,0 +1,68 @@
+package uof
+
+import "testing"
+
+func TestURNPrefixAndType(t *testing.T) {
+	cases := []struct {
+		name       string
+		urn        URN
+		wantPrefix string
+		wantType   string
+	}{
+		{"match", URN("sr:match:12345"), "sr", "match"},
+		{"virtual football", URN("vf:match:1"), "vf", "match"},
+		{"wns", URN("wns:match:1"), "wns", "match"},
+		{"malformed, one segment", URN("sr"), "", ""},
+		{"malformed, two segments", URN("sr:match"), "", ""},
+		{"empty", URN(""), "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.urn.Prefix(); got != c.wantPrefix {
+				t.Errorf("Prefix() = %q, want %q", got, c.wantPrefix)
+			}
+			if got := c.urn.Type(); got != c.wantType {
+				t.Errorf("Type() = %q, want %q", got, c.wantType)
+			}
+		})
+	}
+}
+
+func TestURNIsVirtual(t *testing.T) {
+	cases := []struct {
+		name string
+		urn  URN
+		want bool
+	}{
+		{"virtual football", URN("vf:match:1"), true},
+		{"wns", URN("wns:match:1"), true},
+		{"sportradar", URN("sr:match:1"), false},
+		{"malformed", URN("sr"), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.urn.IsVirtual(); got != c.want {
+				t.Errorf("IsVirtual() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestOddsChangeIsPrematchOnly(t *testing.T) {
+	cases := []struct {
+		name    string
+		product Producer
+		want    bool
+	}{
+		{"ctrl producer", Producer(3), true},
+		{"liveodds producer", Producer(1), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			oc := &OddsChange{Product: c.product}
+			if got := oc.IsPrematchOnly(); got != c.want {
+				t.Errorf("IsPrematchOnly() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}